@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// flushEvery controls how often streaming converters flush their
+// underlying writer, so conversion of large inputs doesn't hold an
+// unbounded amount of buffered output in memory.
+const flushEvery = 1000
+
+// Options carries the flags that affect how a Converter renders its
+// output. Not every converter uses every field.
+type Options struct {
+	HeaderScan int
+
+	// SQL-specific options, used only by the "sql" converter.
+	SQLTable   string
+	SQLDialect string
+	SQLCreate  bool
+	SQLBatch   int
+
+	// Template-specific options, used only by the "tmpl" converter.
+	TemplateSource string
+	TemplateWhole  bool
+}
+
+// Converter turns a stream of records into a specific output format.
+// Built-in formats and third-party ones register themselves with
+// Register, mirroring how database/sql drivers register themselves.
+type Converter interface {
+	// Name is the value users pass to -s to select this converter.
+	Name() string
+	// Extensions lists the file extensions (without the leading dot)
+	// associated with this format; the first is used as the default
+	// output file extension when -o is not given.
+	Extensions() []string
+	Convert(w io.Writer, rows RowSource, opts Options) error
+}
+
+var registry = map[string]Converter{}
+
+// Register adds a Converter under its Name so it can be selected via -s.
+// It is meant to be called from a format's init() function.
+func Register(c Converter) {
+	registry[c.Name()] = c
+}
+
+// lookupConverter returns the Converter registered under name, if any.
+func lookupConverter(name string) (Converter, bool) {
+	c, ok := registry[name]
+	return c, ok
+}
+
+// registeredNames returns the names of all registered converters, sorted,
+// for use in usage and error messages.
+func registeredNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bufferForHeaders reads up to headerScan records from rows to compute a
+// stable union of headers (in first-seen order), so the header row can be
+// written before the bulk of the records are streamed through. headerScan
+// <= 0 means no limit: scan every record before emitting headers. It
+// returns the buffered records so they can be written out afterwards,
+// alongside the source itself which remains positioned right after them.
+func bufferForHeaders(rows RowSource, headerScan int) ([]map[string]interface{}, []string, error) {
+	unlimited := headerScan <= 0
+	capHint := headerScan
+	if unlimited {
+		capHint = 0
+	}
+
+	buffered := make([]map[string]interface{}, 0, capHint)
+	var headers []string
+	seen := make(map[string]bool)
+
+	for unlimited || len(buffered) < headerScan {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				headers = append(headers, key)
+			}
+		}
+		buffered = append(buffered, record)
+	}
+
+	return buffered, headers, nil
+}
+
+// cellValue formats record[header] for a tabular cell. A record missing
+// the column, or explicitly storing a null there, comes through as an
+// empty string rather than Go's "<nil>" zero-value formatting.
+func cellValue(record map[string]interface{}, header string) string {
+	value, ok := record[header]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// chainRows returns a RowSource that yields the already-buffered records
+// first, then continues reading from rest. It lets a converter scan ahead
+// (e.g. to infer headers or a schema) without losing the records it read
+// in the process.
+func chainRows(buffered []map[string]interface{}, rest RowSource) RowSource {
+	return &chainedRows{buffered: buffered, rest: rest}
+}
+
+type chainedRows struct {
+	buffered []map[string]interface{}
+	idx      int
+	rest     RowSource
+}
+
+func (c *chainedRows) Next() (map[string]interface{}, error) {
+	if c.idx < len(c.buffered) {
+		row := c.buffered[c.idx]
+		c.idx++
+		return row, nil
+	}
+	return c.rest.Next()
+}
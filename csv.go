@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+type csvConverter struct{}
+
+func init() {
+	Register(csvConverter{})
+}
+
+func (csvConverter) Name() string         { return "csv" }
+func (csvConverter) Extensions() []string { return []string{"csv"} }
+
+func (csvConverter) Convert(w io.Writer, rows RowSource, opts Options) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	buffered, headers, err := bufferForHeaders(rows, opts.HeaderScan)
+	if err != nil {
+		return err
+	}
+	if len(buffered) == 0 {
+		return fmt.Errorf("no data to write to CSV")
+	}
+
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("failed to write CSV headers: %w", err)
+	}
+
+	writeRow := func(record map[string]interface{}) error {
+		row := make([]string, len(headers))
+		for i, header := range headers {
+			row[i] = cellValue(record, header)
+		}
+		return writer.Write(row)
+	}
+
+	count := 0
+	for _, record := range buffered {
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	return nil
+}
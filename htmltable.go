@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+type htmlConverter struct{}
+
+func init() {
+	Register(htmlConverter{})
+}
+
+func (htmlConverter) Name() string         { return "html" }
+func (htmlConverter) Extensions() []string { return []string{"html"} }
+
+func (htmlConverter) Convert(w io.Writer, rows RowSource, opts Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	buffered, headers, err := bufferForHeaders(rows, opts.HeaderScan)
+	if err != nil {
+		return err
+	}
+	if len(buffered) == 0 {
+		return fmt.Errorf("no data to write to HTML")
+	}
+
+	if _, err := fmt.Fprintln(writer, "<table>"); err != nil {
+		return fmt.Errorf("failed to write HTML table: %w", err)
+	}
+
+	if _, err := fmt.Fprintln(writer, "  <tr>"); err != nil {
+		return fmt.Errorf("failed to write HTML header row: %w", err)
+	}
+	for _, header := range headers {
+		if _, err := fmt.Fprintf(writer, "    <th>%s</th>\n", html.EscapeString(header)); err != nil {
+			return fmt.Errorf("failed to write HTML header cell: %w", err)
+		}
+	}
+	if _, err := fmt.Fprintln(writer, "  </tr>"); err != nil {
+		return fmt.Errorf("failed to write HTML header row: %w", err)
+	}
+
+	writeRow := func(record map[string]interface{}) error {
+		if _, err := fmt.Fprintln(writer, "  <tr>"); err != nil {
+			return err
+		}
+		for _, header := range headers {
+			if _, err := fmt.Fprintf(writer, "    <td>%s</td>\n", html.EscapeString(cellValue(record, header))); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintln(writer, "  </tr>")
+		return err
+	}
+
+	count := 0
+	for _, record := range buffered {
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write HTML row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write HTML row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	if _, err := fmt.Fprintln(writer, "</table>"); err != nil {
+		return fmt.Errorf("failed to write HTML table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OpenInput opens path and returns a RowSource over its records along with
+// an io.Closer the caller must close, auto-detecting the input format
+// (json, ndjson, yaml, or csv) from the file extension, falling back to
+// sniffing its content, unless forced is non-empty.
+func OpenInput(path, forced string) (RowSource, io.Closer, error) {
+	format, err := detectInputFormat(path, forced)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch format {
+	case "json", "ndjson":
+		stream, err := ParseJSON(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stream, stream, nil
+
+	case "yaml", "yml":
+		return openYAMLInput(path)
+
+	case "csv":
+		return openCSVInput(path)
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported input format %q", format)
+	}
+}
+
+// detectInputFormat picks an input format for path: forced, if given,
+// otherwise the file extension, falling back to content sniffing.
+func detectInputFormat(path, forced string) (string, error) {
+	if forced != "" {
+		return strings.ToLower(forced), nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".ndjson":
+		return "ndjson", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	case ".csv":
+		return "csv", nil
+	}
+
+	return sniffInputFormat(path)
+}
+
+// sniffInputFormat reads a buffered prefix of path and guesses its format
+// by trying, in order, JSON, YAML, and finally a CSV heuristic.
+func sniffInputFormat(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for format sniffing: %w", err)
+	}
+	defer file.Close()
+
+	prefix := make([]byte, 65536)
+	n, err := io.ReadFull(file, prefix)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("failed to read file for format sniffing: %w", err)
+	}
+	prefix = prefix[:n]
+
+	if trimmed := bytes.TrimLeft(prefix, " \t\r\n"); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return "json", nil
+	}
+
+	var probe interface{}
+	if err := yaml.Unmarshal(prefix, &probe); err == nil {
+		// Any plain text parses as a YAML scalar, so only a sequence or a
+		// mapping counts as YAML; anything else falls through to CSV.
+		switch probe.(type) {
+		case []interface{}, map[interface{}]interface{}:
+			return "yaml", nil
+		}
+	}
+
+	firstLine := prefix
+	if i := bytes.IndexByte(prefix, '\n'); i >= 0 {
+		firstLine = prefix[:i]
+	}
+	if bytes.Count(firstLine, []byte(",")) > 0 {
+		return "csv", nil
+	}
+
+	return "", fmt.Errorf("could not detect input format for %q; pass -i to force one", path)
+}
+
+func openYAMLInput(path string) (RowSource, io.Closer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open YAML file: %w", err)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	var rows []map[string]interface{}
+	if err := yaml.Unmarshal(data, &rows); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to decode YAML data: %w", err)
+	}
+
+	for i, row := range rows {
+		rows[i] = normalizeYAML(row).(map[string]interface{})
+	}
+
+	return &sliceRowSource{rows: rows}, file, nil
+}
+
+// normalizeYAML recursively converts the map[interface{}]interface{} and
+// []interface{} shapes that yaml.v2 decodes nested values into back to
+// the map[string]interface{}/[]interface{} shape every converter (and
+// Flatten) expects from a JSON decode. It also widens the integer types
+// yaml.v2 uses for whole numbers (int, int64) to float64, since that's
+// the only numeric type encoding/json ever produces and the SQL
+// converter's type switches (widenColumnType, literal) only recognize
+// float64 as numeric.
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			normalized[fmt.Sprintf("%v", key)] = normalizeYAML(val)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			normalized[key] = normalizeYAML(val)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeYAML(item)
+		}
+		return normalized
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return value
+	}
+}
+
+func openCSVInput(path string) (RowSource, io.Closer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	headers, err := reader.Read()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to read CSV headers: %w", err)
+	}
+
+	return &csvRowSource{reader: reader, headers: headers}, file, nil
+}
+
+// csvRowSource adapts a CSV file to RowSource, pairing each row's cells
+// with the header row read up front.
+type csvRowSource struct {
+	reader  *csv.Reader
+	headers []string
+}
+
+func (c *csvRowSource) Next() (map[string]interface{}, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(map[string]interface{}, len(c.headers))
+	for i, header := range c.headers {
+		if i < len(record) {
+			row[header] = record[i]
+		} else {
+			row[header] = ""
+		}
+	}
+	return row, nil
+}
+
+// sliceRowSource adapts an already-decoded slice of records to RowSource,
+// for formats like YAML that aren't streamed incrementally.
+type sliceRowSource struct {
+	rows []map[string]interface{}
+	idx  int
+}
+
+func (s *sliceRowSource) Next() (map[string]interface{}, error) {
+	if s.idx >= len(s.rows) {
+		return nil, io.EOF
+	}
+	row := s.rows[s.idx]
+	s.idx++
+	return row, nil
+}
@@ -1,262 +1,110 @@
-package main
-
-import (
-	"encoding/csv"
-	"encoding/json"
-	"flag"
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
-
-	"gopkg.in/yaml.v2"
-)
-
-func main() {
-	var jsonFile, outputType, outputFile string
-
-	flag.StringVar(&jsonFile, "f", "", "Path to the JSON file")
-	flag.StringVar(&outputType, "s", "csv", "Output type: csv, txt, md, sql, or yaml (default: csv)")
-	flag.StringVar(&outputFile, "o", "", "Path to the output file (optional)")
-
-	flag.Parse()
-
-	if jsonFile == "" {
-		fmt.Println("Error: Please specify the JSON file using -f")
-		flag.Usage()
-		return
-	}
-
-	if _, err := os.Stat(jsonFile); os.IsNotExist(err) {
-		fmt.Printf("Error: The file '%s' does not exist. Please check the -f file path.\n", jsonFile)
-		return
-	}
-
-	if outputFile == "" {
-		outputFile = strings.TrimSuffix(jsonFile, filepath.Ext(jsonFile)) + "." + outputType
-	}
-
-	var err error
-	data, err := ParseJSON(jsonFile)
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return
-	}
-
-	switch outputType {
-	case "csv":
-		err = ConvertToCSV(data, outputFile)
-	case "txt":
-		err = ConvertToTXT(data, outputFile)
-	case "md":
-		err = ConvertToMarkdown(data, outputFile)
-	case "sql":
-		err = ConvertToSQL(data, outputFile)
-	case "yaml":
-		err = ConvertToYAML(data, outputFile)
-	default:
-		fmt.Printf("Error: Unsupported output type '%s'. Supported types are: csv, txt, md, sql, yaml\n", outputType)
-		return
-	}
-
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return
-	}
-
-	fmt.Printf("Conversion successful. Output file: %s\n", outputFile)
-}
-
-func ParseJSON(jsonFile string) ([]map[string]interface{}, error) {
-	file, err := os.Open(jsonFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open JSON file: %w", err)
-	}
-	defer file.Close()
-
-	var data []map[string]interface{}
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON data: %w", err)
-	}
-	return data, nil
-}
-
-func Flatten(input map[string]interface{}, prefix string, output map[string]interface{}) {
-	for key, value := range input {
-		fullKey := key
-		if prefix != "" {
-			fullKey = prefix + "." + key
-		}
-		switch v := value.(type) {
-		case map[string]interface{}:
-			Flatten(v, fullKey, output)
-		case []interface{}:
-			for i, item := range v {
-				Flatten(map[string]interface{}{fmt.Sprintf("%d", i): item}, fullKey, output)
-			}
-		default:
-			output[fullKey] = value
-		}
-	}
-}
-
-func ConvertToCSV(data []map[string]interface{}, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create CSV file: %w", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	if len(data) == 0 {
-		return fmt.Errorf("no data to write to CSV")
-	}
-
-	headers := make([]string, 0, len(data[0]))
-	for key := range data[0] {
-		headers = append(headers, key)
-	}
-
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
-	}
-
-	for _, record := range data {
-		row := make([]string, len(headers))
-		for i, header := range headers {
-			row[i] = fmt.Sprintf("%v", record[header])
-		}
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("failed to write CSV row: %w", err)
-		}
-	}
-
-	return nil
-}
-
-func ConvertToTXT(data []map[string]interface{}, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create TXT file: %w", err)
-	}
-	defer file.Close()
-
-	for _, record := range data {
-		for key, value := range record {
-			_, err := fmt.Fprintf(file, "%s: %v\n", key, value)
-			if err != nil {
-				return fmt.Errorf("failed to write TXT content: %w", err)
-			}
-		}
-		_, err = fmt.Fprintln(file)
-		if err != nil {
-			return fmt.Errorf("failed to write TXT content: %w", err)
-		}
-	}
-
-	return nil
-}
-
-func ConvertToMarkdown(data []map[string]interface{}, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create Markdown file: %w", err)
-	}
-	defer file.Close()
-
-	if len(data) == 0 {
-		return fmt.Errorf("no data to write to Markdown")
-	}
-
-	headers := make([]string, 0, len(data[0]))
-	for key := range data[0] {
-		headers = append(headers, key)
-	}
-
-	// Write the header row
-	_, err = fmt.Fprintln(file, "| "+strings.Join(headers, " | ")+" |")
-	if err != nil {
-		return fmt.Errorf("failed to write Markdown headers: %w", err)
-	}
-
-	// Write the separator row
-	separators := make([]string, len(headers))
-	for i := range separators {
-		separators[i] = "---"
-	}
-	_, err = fmt.Fprintln(file, "| "+strings.Join(separators, " | ")+" |")
-	if err != nil {
-		return fmt.Errorf("failed to write Markdown separators: %w", err)
-	}
-
-	// Write the data rows
-	for _, record := range data {
-		row := make([]string, len(headers))
-		for i, header := range headers {
-			row[i] = fmt.Sprintf("%v", record[header])
-		}
-		_, err = fmt.Fprintln(file, "| "+strings.Join(row, " | ")+" |")
-		if err != nil {
-			return fmt.Errorf("failed to write Markdown row: %w", err)
-		}
-	}
-
-	return nil
-}
-
-func ConvertToSQL(data []map[string]interface{}, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create SQL file: %w", err)
-	}
-	defer file.Close()
-
-	if len(data) == 0 {
-		return fmt.Errorf("no data to write to SQL")
-	}
-
-	tableName := "your_table_name" // You might want to make this configurable
-	headers := make([]string, 0, len(data[0]))
-	for key := range data[0] {
-		headers = append(headers, key)
-	}
-
-	for _, record := range data {
-		columns := strings.Join(headers, ", ")
-		values := make([]string, len(headers))
-		for i, header := range headers {
-			values[i] = fmt.Sprintf("'%v'", record[header])
-		}
-		valuesStr := strings.Join(values, ", ")
-		sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", tableName, columns, valuesStr)
-		_, err := fmt.Fprintln(file, sql)
-		if err != nil {
-			return fmt.Errorf("failed to write SQL statement: %w", err)
-		}
-	}
-
-	return nil
-}
-
-func ConvertToYAML(data []map[string]interface{}, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create YAML file: %w", err)
-	}
-	defer file.Close()
-
-	yamlData, err := yaml.Marshal(data)
-	if err != nil {
-		return fmt.Errorf("failed to marshal data to YAML: %w", err)
-	}
-
-	_, err = file.Write(yamlData)
-	if err != nil {
-		return fmt.Errorf("failed to write YAML file: %w", err)
-	}
-
-	return nil
-}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var jsonFile, outputType, outputFile, selectFields, arrayMode, inputType string
+	var sqlTable, sqlDialect string
+	var templateFile, templateInline, templateMode string
+	var headerScan, sqlBatch int
+	var flatten, sqlCreate bool
+
+	flag.StringVar(&jsonFile, "f", "", "Path to the input file")
+	flag.StringVar(&outputType, "s", "csv", "Output type: csv, txt, md, sql, yaml, jsonl, toml, xml, html, parquet, or tmpl (default: csv)")
+	flag.StringVar(&outputFile, "o", "", "Path to the output file (optional)")
+	flag.IntVar(&headerScan, "header-scan", 100, "Number of records to buffer when inferring CSV/Markdown/HTML/Parquet headers")
+	flag.BoolVar(&flatten, "flatten", false, "Flatten nested objects/arrays into dotted keys before conversion")
+	flag.StringVar(&selectFields, "select", "", "Comma-separated list of dotted field paths to project out of each record (implies -flatten)")
+	flag.StringVar(&arrayMode, "array-mode", "index", "How to flatten arrays: index, explode, or join:<sep>")
+	flag.StringVar(&sqlTable, "sql-table", "your_table_name", "Table name to use for -s sql output")
+	flag.StringVar(&sqlDialect, "sql-dialect", "postgres", "SQL dialect for -s sql output: postgres, mysql, sqlite, or mssql")
+	flag.BoolVar(&sqlCreate, "sql-create", false, "Emit a CREATE TABLE statement inferred from the data before the INSERTs (requires scanning the whole dataset)")
+	flag.IntVar(&sqlBatch, "sql-batch", 1, "Number of rows to batch into each multi-row INSERT statement for -s sql output")
+	flag.StringVar(&inputType, "i", "", "Force the input format instead of auto-detecting it: json, ndjson, yaml, or csv")
+	flag.StringVar(&templateFile, "template", "", "Path to a Go text/template file for -s tmpl output")
+	flag.StringVar(&templateInline, "template-inline", "", "Inline Go text/template string for -s tmpl output")
+	flag.StringVar(&templateMode, "template-mode", "per-record", "Template invocation mode for -s tmpl output: per-record or whole")
+
+	flag.Parse()
+
+	if jsonFile == "" {
+		fmt.Println("Error: Please specify the input file using -f")
+		flag.Usage()
+		return
+	}
+
+	if _, err := os.Stat(jsonFile); os.IsNotExist(err) {
+		fmt.Printf("Error: The file '%s' does not exist. Please check the -f file path.\n", jsonFile)
+		return
+	}
+
+	converter, ok := lookupConverter(outputType)
+	if !ok {
+		fmt.Printf("Error: Unsupported output type '%s'. Supported types are: %s\n", outputType, strings.Join(registeredNames(), ", "))
+		return
+	}
+
+	if outputFile == "" {
+		ext := outputType
+		if exts := converter.Extensions(); len(exts) > 0 {
+			ext = exts[0]
+		}
+		outputFile = strings.TrimSuffix(jsonFile, filepath.Ext(jsonFile)) + "." + ext
+	}
+
+	input, closer, err := OpenInput(jsonFile, inputType)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer closer.Close()
+
+	var selectPaths []string
+	if selectFields != "" {
+		selectPaths = strings.Split(selectFields, ",")
+	}
+
+	rows := input
+	if flatten || len(selectPaths) > 0 {
+		rows = NewPipeline(input, arrayMode, selectPaths)
+	}
+
+	out, err := os.Create(outputFile)
+	if err != nil {
+		fmt.Printf("Error: failed to create output file: %v\n", err)
+		return
+	}
+	defer out.Close()
+
+	templateSource := templateInline
+	if templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			fmt.Printf("Error: failed to read template file: %v\n", err)
+			return
+		}
+		templateSource = string(data)
+	}
+
+	opts := Options{
+		HeaderScan:     headerScan,
+		SQLTable:       sqlTable,
+		SQLDialect:     sqlDialect,
+		SQLCreate:      sqlCreate,
+		SQLBatch:       sqlBatch,
+		TemplateSource: templateSource,
+		TemplateWhole:  templateMode == "whole",
+	}
+	if err := converter.Convert(out, rows, opts); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Conversion successful. Output file: %s\n", outputFile)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type jsonLinesConverter struct{}
+
+func init() {
+	Register(jsonLinesConverter{})
+}
+
+func (jsonLinesConverter) Name() string         { return "jsonl" }
+func (jsonLinesConverter) Extensions() []string { return []string{"jsonl", "ndjson"} }
+
+func (jsonLinesConverter) Convert(w io.Writer, rows RowSource, _ Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	enc := json.NewEncoder(writer)
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to write JSON Lines record: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush JSON Lines file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
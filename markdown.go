@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type markdownConverter struct{}
+
+func init() {
+	Register(markdownConverter{})
+}
+
+func (markdownConverter) Name() string         { return "md" }
+func (markdownConverter) Extensions() []string { return []string{"md"} }
+
+func (markdownConverter) Convert(w io.Writer, rows RowSource, opts Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	buffered, headers, err := bufferForHeaders(rows, opts.HeaderScan)
+	if err != nil {
+		return err
+	}
+	if len(buffered) == 0 {
+		return fmt.Errorf("no data to write to Markdown")
+	}
+
+	if _, err := fmt.Fprintln(writer, "| "+strings.Join(headers, " | ")+" |"); err != nil {
+		return fmt.Errorf("failed to write Markdown headers: %w", err)
+	}
+
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	if _, err := fmt.Fprintln(writer, "| "+strings.Join(separators, " | ")+" |"); err != nil {
+		return fmt.Errorf("failed to write Markdown separators: %w", err)
+	}
+
+	writeRow := func(record map[string]interface{}) error {
+		row := make([]string, len(headers))
+		for i, header := range headers {
+			row[i] = cellValue(record, header)
+		}
+		_, err := fmt.Fprintln(writer, "| "+strings.Join(row, " | ")+" |")
+		return err
+	}
+
+	count := 0
+	for _, record := range buffered {
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write Markdown row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeRow(record); err != nil {
+			return fmt.Errorf("failed to write Markdown row: %w", err)
+		}
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	return nil
+}
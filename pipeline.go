@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowSource yields records one at a time, returning io.EOF once exhausted.
+// Both RecordStream and Pipeline implement it, so converters don't need to
+// care whether flattening/selection is in play.
+type RowSource interface {
+	Next() (map[string]interface{}, error)
+}
+
+// Pipeline wraps a RecordStream with the flatten/select preprocessing
+// stage. A single input record can expand into multiple output rows when
+// arrayMode is "explode", so Pipeline buffers those in pending.
+type Pipeline struct {
+	stream      RowSource
+	arrayMode   string
+	selectPaths []string
+	pending     []map[string]interface{}
+}
+
+// NewPipeline returns a Pipeline that flattens every record read from
+// stream according to arrayMode, then, if selectPaths is non-empty,
+// projects each flattened record down to just those dotted field paths.
+func NewPipeline(stream RowSource, arrayMode string, selectPaths []string) *Pipeline {
+	return &Pipeline{stream: stream, arrayMode: arrayMode, selectPaths: selectPaths}
+}
+
+// Next returns the next flattened (and, if configured, projected) row.
+func (p *Pipeline) Next() (map[string]interface{}, error) {
+	for len(p.pending) == 0 {
+		record, err := p.stream.Next()
+		if err != nil {
+			return nil, err
+		}
+		p.pending = Flatten(record, p.arrayMode)
+	}
+
+	row := p.pending[0]
+	p.pending = p.pending[1:]
+
+	if len(p.selectPaths) > 0 {
+		row = SelectFields(row, p.selectPaths)
+	}
+	return row, nil
+}
+
+// Flatten collapses a nested record into one or more flat
+// map[string]interface{} rows with dotted keys (e.g. "user.name",
+// "orders.0.id"). arrayMode controls how arrays are handled:
+//
+//   - "index" (default): each element becomes its own numeric-keyed path,
+//     matching the original behavior.
+//   - "explode": one output row per element, cartesian-produced against
+//     any other arrays and the record's scalar fields.
+//   - "join:<sep>": arrays of scalars are joined into a single delimited
+//     cell instead of being split into separate keys.
+func Flatten(input map[string]interface{}, arrayMode string) []map[string]interface{} {
+	rows := []map[string]interface{}{{}}
+	for key, value := range input {
+		rows = flattenValue(rows, key, value, arrayMode)
+	}
+	return rows
+}
+
+func flattenValue(rows []map[string]interface{}, prefix string, value interface{}, arrayMode string) []map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			rows = flattenValue(rows, prefix+"."+key, val, arrayMode)
+		}
+		return rows
+	case []interface{}:
+		return flattenArray(rows, prefix, v, arrayMode)
+	default:
+		for _, row := range rows {
+			row[prefix] = value
+		}
+		return rows
+	}
+}
+
+func flattenArray(rows []map[string]interface{}, prefix string, items []interface{}, arrayMode string) []map[string]interface{} {
+	mode, sep := splitArrayMode(arrayMode)
+
+	switch mode {
+	case "join":
+		joined := make([]string, 0, len(items))
+		allScalar := true
+		for _, item := range items {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				allScalar = false
+			default:
+				joined = append(joined, fmt.Sprintf("%v", item))
+			}
+		}
+		if allScalar {
+			for _, row := range rows {
+				row[prefix] = strings.Join(joined, sep)
+			}
+			return rows
+		}
+		// Non-scalar elements can't be joined into one cell; fall back to
+		// the index behavior for this array.
+		for i, item := range items {
+			rows = flattenValue(rows, fmt.Sprintf("%s.%d", prefix, i), item, arrayMode)
+		}
+		return rows
+
+	case "explode":
+		exploded := make([]map[string]interface{}, 0, len(rows)*len(items))
+		for _, row := range rows {
+			for _, item := range items {
+				exploded = append(exploded, flattenValue([]map[string]interface{}{cloneRow(row)}, prefix, item, arrayMode)...)
+			}
+		}
+		return exploded
+
+	default: // "index"
+		for i, item := range items {
+			rows = flattenValue(rows, fmt.Sprintf("%s.%d", prefix, i), item, arrayMode)
+		}
+		return rows
+	}
+}
+
+func splitArrayMode(arrayMode string) (mode, sep string) {
+	if strings.HasPrefix(arrayMode, "join:") {
+		return "join", strings.TrimPrefix(arrayMode, "join:")
+	}
+	return arrayMode, ""
+}
+
+func cloneRow(row map[string]interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		clone[k] = v
+	}
+	return clone
+}
+
+// SelectFields projects record down to just the given dotted field paths.
+// Paths absent from record come through as nil. The result is a map, so
+// the order in which paths were given is not kept.
+func SelectFields(record map[string]interface{}, paths []string) map[string]interface{} {
+	selected := make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		selected[path] = record[path]
+	}
+	return selected
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFlattenIndexMode(t *testing.T) {
+	input := map[string]interface{}{
+		"name": "alice",
+		"address": map[string]interface{}{
+			"city": "NYC",
+			"zip":  "10001",
+		},
+		"tags": []interface{}{"a", "b"},
+	}
+
+	rows := Flatten(input, "index")
+	if len(rows) != 1 {
+		t.Fatalf("index mode: got %d rows, want 1", len(rows))
+	}
+
+	want := map[string]interface{}{
+		"name":         "alice",
+		"address.city": "NYC",
+		"address.zip":  "10001",
+		"tags.0":       "a",
+		"tags.1":       "b",
+	}
+	if !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("index mode: got %v, want %v", rows[0], want)
+	}
+}
+
+func TestFlattenExplodeMode(t *testing.T) {
+	input := map[string]interface{}{
+		"id":   "1",
+		"tags": []interface{}{"a", "b", "c"},
+	}
+
+	rows := Flatten(input, "explode")
+	if len(rows) != 3 {
+		t.Fatalf("explode mode: got %d rows, want 3", len(rows))
+	}
+
+	var got []string
+	for _, row := range rows {
+		if row["id"] != "1" {
+			t.Errorf("explode mode: row %v missing untouched scalar field", row)
+		}
+		tag, ok := row["tags"].(string)
+		if !ok {
+			t.Fatalf("explode mode: row %v has no scalar \"tags\" key", row)
+		}
+		got = append(got, tag)
+	}
+	sort.Strings(got)
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("explode mode: got tags %v, want %v", got, want)
+	}
+}
+
+func TestFlattenExplodeCartesianProduct(t *testing.T) {
+	input := map[string]interface{}{
+		"a": []interface{}{"x", "y"},
+		"b": []interface{}{1.0, 2.0},
+	}
+
+	rows := Flatten(input, "explode")
+	if len(rows) != 4 {
+		t.Fatalf("explode mode: got %d rows, want 4 (cartesian product)", len(rows))
+	}
+}
+
+func TestFlattenJoinMode(t *testing.T) {
+	input := map[string]interface{}{
+		"tags": []interface{}{"a", "b", "c"},
+	}
+
+	rows := Flatten(input, "join:|")
+	if len(rows) != 1 {
+		t.Fatalf("join mode: got %d rows, want 1", len(rows))
+	}
+	if got := rows[0]["tags"]; got != "a|b|c" {
+		t.Errorf("join mode: got tags %q, want %q", got, "a|b|c")
+	}
+}
+
+func TestFlattenJoinModeFallsBackOnNonScalar(t *testing.T) {
+	input := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "1"},
+			map[string]interface{}{"id": "2"},
+		},
+	}
+
+	rows := Flatten(input, "join:|")
+	if len(rows) != 1 {
+		t.Fatalf("join mode fallback: got %d rows, want 1", len(rows))
+	}
+	want := map[string]interface{}{
+		"items.0.id": "1",
+		"items.1.id": "2",
+	}
+	if !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("join mode fallback: got %v, want %v", rows[0], want)
+	}
+}
+
+func TestSelectFieldsMissingPathComesThroughAsNil(t *testing.T) {
+	record := map[string]interface{}{"a": 1.0}
+
+	got := SelectFields(record, []string{"a", "b"})
+	want := map[string]interface{}{"a": 1.0, "b": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
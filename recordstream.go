@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RecordStream yields one record at a time from a JSON input, so callers
+// never have to hold the whole dataset in memory. It supports a top-level
+// JSON array (`[{...}, {...}]`) as well as newline-delimited JSON, auto-
+// detected by sniffing the first non-whitespace byte of the input.
+type RecordStream struct {
+	file    *os.File
+	reader  *bufio.Reader
+	ndjson  bool
+	dec     *json.Decoder
+	started bool
+	done    bool
+}
+
+// ParseJSON opens jsonFile and returns a RecordStream over its records.
+// The caller must call Close when finished with the stream.
+func ParseJSON(jsonFile string) (*RecordStream, error) {
+	file, err := os.Open(jsonFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON file: %w", err)
+	}
+
+	reader := bufio.NewReader(file)
+	first, err := peekNonSpace(reader)
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, fmt.Errorf("failed to sniff JSON file: %w", err)
+	}
+
+	stream := &RecordStream{
+		file:   file,
+		reader: reader,
+		ndjson: first != '[',
+		dec:    json.NewDecoder(reader),
+	}
+	return stream, nil
+}
+
+// peekNonSpace returns the first non-whitespace byte in r without
+// consuming anything beyond the whitespace that precedes it.
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		default:
+			return b, r.UnreadByte()
+		}
+	}
+}
+
+// Next decodes and returns the next record in the stream. It returns
+// io.EOF once the stream is exhausted.
+func (s *RecordStream) Next() (map[string]interface{}, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	if s.ndjson {
+		return s.nextNDJSON()
+	}
+	return s.nextArrayElement()
+}
+
+func (s *RecordStream) nextNDJSON() (map[string]interface{}, error) {
+	for {
+		var record map[string]interface{}
+		err := s.dec.Decode(&record)
+		if err == io.EOF {
+			s.done = true
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode NDJSON record: %w", err)
+		}
+		if record == nil {
+			continue
+		}
+		return record, nil
+	}
+}
+
+func (s *RecordStream) nextArrayElement() (map[string]interface{}, error) {
+	if !s.started {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read opening JSON array token: %w", err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("expected JSON input to start with '[', got %v", tok)
+		}
+		s.started = true
+	}
+
+	if !s.dec.More() {
+		// Consume the closing ']' so a well-formed input is fully read.
+		if _, err := s.dec.Token(); err != nil {
+			return nil, fmt.Errorf("failed to read closing JSON array token: %w", err)
+		}
+		s.done = true
+		return nil, io.EOF
+	}
+
+	var record map[string]interface{}
+	if err := s.dec.Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON array element: %w", err)
+	}
+	return record, nil
+}
+
+// Close releases the underlying file handle.
+func (s *RecordStream) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type sqlConverter struct{}
+
+func init() {
+	Register(sqlConverter{})
+}
+
+func (sqlConverter) Name() string         { return "sql" }
+func (sqlConverter) Extensions() []string { return []string{"sql"} }
+
+func (sqlConverter) Convert(w io.Writer, rows RowSource, opts Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	dialect, ok := sqlDialects[opts.SQLDialect]
+	if !ok {
+		return fmt.Errorf("unsupported SQL dialect %q (want postgres, mysql, sqlite, or mssql)", opts.SQLDialect)
+	}
+
+	table := opts.SQLTable
+	if table == "" {
+		table = "your_table_name"
+	}
+
+	batchSize := opts.SQLBatch
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	if opts.SQLCreate {
+		return convertWithCreateTable(writer, dialect, table, rows, batchSize)
+	}
+
+	buffered, headers, err := bufferForHeaders(rows, opts.HeaderScan)
+	if err != nil {
+		return err
+	}
+	if len(buffered) == 0 {
+		return fmt.Errorf("no data to write to SQL")
+	}
+
+	return writeInserts(writer, dialect, table, headers, chainRows(buffered, rows), batchSize)
+}
+
+// convertWithCreateTable scans every record to infer a column schema,
+// emits a CREATE TABLE statement for it, then streams INSERT statements
+// for the same records. Unlike the default path, this requires buffering
+// the whole dataset, since the schema isn't known until it's all been
+// seen.
+func convertWithCreateTable(writer *bufio.Writer, dialect sqlDialect, table string, rows RowSource, batchSize int) error {
+	var buffered []map[string]interface{}
+	var headers []string
+	seen := make(map[string]bool)
+	columnTypes := make(map[string]sqlColumnType)
+
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for key, value := range record {
+			if !seen[key] {
+				seen[key] = true
+				headers = append(headers, key)
+			}
+			columnTypes[key] = widenColumnType(columnTypes[key], value)
+		}
+		buffered = append(buffered, record)
+	}
+
+	if len(buffered) == 0 {
+		return fmt.Errorf("no data to write to SQL")
+	}
+
+	if err := writeCreateTable(writer, dialect, table, headers, columnTypes); err != nil {
+		return err
+	}
+
+	return writeInserts(writer, dialect, table, headers, chainRows(buffered, rows), batchSize)
+}
+
+func writeCreateTable(writer *bufio.Writer, dialect sqlDialect, table string, headers []string, columnTypes map[string]sqlColumnType) error {
+	columns := make([]string, len(headers))
+	for i, header := range headers {
+		columns[i] = fmt.Sprintf("  %s %s", dialect.quoteIdent(header), dialect.typeName(columnTypes[header]))
+	}
+	_, err := fmt.Fprintf(writer, "CREATE TABLE %s (\n%s\n);\n", dialect.quoteIdent(table), strings.Join(columns, ",\n"))
+	if err != nil {
+		return fmt.Errorf("failed to write CREATE TABLE statement: %w", err)
+	}
+	return nil
+}
+
+// writeInserts streams rows as INSERT statements, batching up to
+// batchSize rows into each multi-row VALUES list.
+func writeInserts(writer *bufio.Writer, dialect sqlDialect, table string, headers []string, rows RowSource, batchSize int) error {
+	columns := make([]string, len(headers))
+	for i, header := range headers {
+		columns[i] = dialect.quoteIdent(header)
+	}
+	columnList := strings.Join(columns, ", ")
+
+	batch := make([]string, 0, batchSize)
+	flushBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := fmt.Fprintf(writer, "INSERT INTO %s (%s) VALUES %s;\n", dialect.quoteIdent(table), columnList, strings.Join(batch, ", "))
+		batch = batch[:0]
+		return err
+	}
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		values := make([]string, len(headers))
+		for i, header := range headers {
+			values[i] = dialect.literal(record[header])
+		}
+		batch = append(batch, "("+strings.Join(values, ", ")+")")
+
+		if len(batch) == batchSize {
+			if err := flushBatch(); err != nil {
+				return fmt.Errorf("failed to write SQL batch: %w", err)
+			}
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			writer.Flush()
+		}
+	}
+
+	if err := flushBatch(); err != nil {
+		return fmt.Errorf("failed to write SQL batch: %w", err)
+	}
+
+	return nil
+}
+
+// sqlColumnType is the narrowest inferred type for a CREATE TABLE column,
+// ordered from most specific to most general.
+type sqlColumnType int
+
+const (
+	colUnset sqlColumnType = iota
+	colBoolean
+	colInteger
+	colFloat
+	colTimestamp
+	colText
+	colJSON
+)
+
+// widenColumnType folds one more observed value into current, returning
+// the narrowest type that still fits every value seen so far. A nested
+// object or array always widens the column to JSON, since that's the only
+// type able to hold it. Integers mixed with floats widen to float; any
+// other mix of types widens to text.
+func widenColumnType(current sqlColumnType, value interface{}) sqlColumnType {
+	if value == nil {
+		return current
+	}
+
+	var observed sqlColumnType
+	switch v := value.(type) {
+	case bool:
+		observed = colBoolean
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			observed = colInteger
+		} else {
+			observed = colFloat
+		}
+	case string:
+		if _, err := time.Parse(time.RFC3339, v); err == nil {
+			observed = colTimestamp
+		} else {
+			observed = colText
+		}
+	case map[string]interface{}, []interface{}:
+		observed = colJSON
+	default:
+		observed = colText
+	}
+
+	if current == colUnset || observed == current {
+		return observed
+	}
+	isNumeric := func(t sqlColumnType) bool { return t == colInteger || t == colFloat }
+	switch {
+	case observed == colJSON || current == colJSON:
+		return colJSON
+	case isNumeric(observed) && isNumeric(current):
+		return colFloat
+	default:
+		return colText
+	}
+}
+
+// sqlDialect controls identifier quoting, literal formatting, and column
+// type names for a specific database.
+type sqlDialect struct {
+	quoteIdent  func(string) string
+	quoteString func(string) string
+	boolLiteral func(bool) string
+	typeName    func(sqlColumnType) string
+}
+
+// literal formats a value as it should appear inside a VALUES list:
+// NULL for nil, unquoted for numerics/booleans, and JSON-encoded for
+// nested objects/arrays since SQL has no literal syntax for them.
+func (d sqlDialect) literal(value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return d.boolLiteral(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return d.quoteString(v)
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return d.quoteString(fmt.Sprintf("%v", v))
+		}
+		return d.quoteString(string(encoded))
+	default:
+		return d.quoteString(fmt.Sprintf("%v", v))
+	}
+}
+
+var sqlDialects = map[string]sqlDialect{
+	"postgres": {
+		quoteIdent:  func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` },
+		quoteString: func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+		boolLiteral: func(b bool) string {
+			if b {
+				return "TRUE"
+			}
+			return "FALSE"
+		},
+		typeName: func(t sqlColumnType) string {
+			switch t {
+			case colBoolean:
+				return "BOOLEAN"
+			case colInteger:
+				return "BIGINT"
+			case colFloat:
+				return "DOUBLE PRECISION"
+			case colTimestamp:
+				return "TIMESTAMP"
+			case colJSON:
+				return "JSONB"
+			default:
+				return "TEXT"
+			}
+		},
+	},
+	"mysql": {
+		quoteIdent:  func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" },
+		quoteString: func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+		boolLiteral: func(b bool) string {
+			if b {
+				return "TRUE"
+			}
+			return "FALSE"
+		},
+		typeName: func(t sqlColumnType) string {
+			switch t {
+			case colBoolean:
+				return "BOOLEAN"
+			case colInteger:
+				return "BIGINT"
+			case colFloat:
+				return "DOUBLE"
+			case colTimestamp:
+				return "DATETIME"
+			case colJSON:
+				return "JSON"
+			default:
+				return "TEXT"
+			}
+		},
+	},
+	"sqlite": {
+		quoteIdent:  func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` },
+		quoteString: func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+		boolLiteral: func(b bool) string {
+			if b {
+				return "1"
+			}
+			return "0"
+		},
+		typeName: func(t sqlColumnType) string {
+			switch t {
+			case colBoolean:
+				return "BOOLEAN"
+			case colInteger:
+				return "INTEGER"
+			case colFloat:
+				return "REAL"
+			default:
+				return "TEXT"
+			}
+		},
+	},
+	"mssql": {
+		quoteIdent:  func(name string) string { return "[" + strings.ReplaceAll(name, "]", "]]") + "]" },
+		quoteString: func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" },
+		boolLiteral: func(b bool) string {
+			if b {
+				return "1"
+			}
+			return "0"
+		},
+		typeName: func(t sqlColumnType) string {
+			switch t {
+			case colBoolean:
+				return "BIT"
+			case colInteger:
+				return "BIGINT"
+			case colFloat:
+				return "FLOAT"
+			case colTimestamp:
+				return "DATETIME2"
+			default:
+				return "NVARCHAR(MAX)"
+			}
+		},
+	},
+}
@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+func TestWidenColumnType(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []interface{}
+		want   sqlColumnType
+	}{
+		{"bool", []interface{}{true}, colBoolean},
+		{"integer", []interface{}{42.0}, colInteger},
+		{"float", []interface{}{3.5}, colFloat},
+		{"integer then float widens", []interface{}{42.0, 3.5}, colFloat},
+		{"timestamp", []interface{}{"2024-01-02T15:04:05Z"}, colTimestamp},
+		{"text", []interface{}{"hello"}, colText},
+		{"nested object widens to json", []interface{}{map[string]interface{}{"a": 1.0}}, colJSON},
+		{"nested array widens to json", []interface{}{[]interface{}{1.0, 2.0}}, colJSON},
+		{"integer then text widens", []interface{}{42.0, "hello"}, colText},
+		{"nulls are ignored", []interface{}{nil, 42.0, nil}, colInteger},
+		{"never widens back down", []interface{}{"hello", 42.0}, colText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sqlColumnType
+			for _, v := range tt.values {
+				got = widenColumnType(got, v)
+			}
+			if got != tt.want {
+				t.Errorf("widenColumnType(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialectLiteral(t *testing.T) {
+	dialect := sqlDialects["postgres"]
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"nil is NULL", nil, "NULL"},
+		{"bool true", true, "TRUE"},
+		{"bool false", false, "FALSE"},
+		{"integer is unquoted", 42.0, "42"},
+		{"float is unquoted", 3.5, "3.5"},
+		{"string is quoted and escaped", "it's", "'it''s'"},
+		{"nested object is json-encoded and quoted", map[string]interface{}{"a": 1.0}, `'{"a":1}'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dialect.literal(tt.value); got != tt.want {
+				t.Errorf("literal(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialectTypeNames(t *testing.T) {
+	tests := []struct {
+		dialect string
+		colType sqlColumnType
+		want    string
+	}{
+		{"postgres", colInteger, "BIGINT"},
+		{"postgres", colJSON, "JSONB"},
+		{"mysql", colTimestamp, "DATETIME"},
+		{"sqlite", colInteger, "INTEGER"},
+		{"mssql", colBoolean, "BIT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+tt.want, func(t *testing.T) {
+			dialect, ok := sqlDialects[tt.dialect]
+			if !ok {
+				t.Fatalf("unknown dialect %q", tt.dialect)
+			}
+			if got := dialect.typeName(tt.colType); got != tt.want {
+				t.Errorf("typeName(%v) = %q, want %q", tt.colType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+var templateFuncs = template.FuncMap{
+	"toJSON": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"toYAML": func(v interface{}) (string, error) {
+		b, err := yaml.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+	"env": os.Getenv,
+}
+
+type templateConverter struct{}
+
+func init() {
+	Register(templateConverter{})
+}
+
+func (templateConverter) Name() string         { return "tmpl" }
+func (templateConverter) Extensions() []string { return []string{"txt"} }
+
+// Convert renders each record (or, with TemplateWhole, the whole dataset
+// as .Records) through a user-supplied Go text/template, so users can
+// emit HTML reports, INI files, Terraform vars, or any other bespoke
+// format without writing a new Converter.
+func (templateConverter) Convert(w io.Writer, rows RowSource, opts Options) error {
+	if opts.TemplateSource == "" {
+		return fmt.Errorf("the tmpl output type requires -template or -template-inline")
+	}
+
+	tmpl, err := template.New("json2file").Funcs(templateFuncs).Parse(opts.TemplateSource)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if opts.TemplateWhole {
+		var records []map[string]interface{}
+		for {
+			record, err := rows.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+
+		data := struct {
+			Records []map[string]interface{}
+		}{Records: records}
+		if err := tmpl.Execute(w, data); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		return nil
+	}
+
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tmpl.Execute(writer, record); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush template output: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
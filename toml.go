@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+type tomlConverter struct{}
+
+func init() {
+	Register(tomlConverter{})
+}
+
+func (tomlConverter) Name() string         { return "toml" }
+func (tomlConverter) Extensions() []string { return []string{"toml"} }
+
+func (tomlConverter) Convert(w io.Writer, rows RowSource, _ Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	enc := toml.NewEncoder(writer)
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Each record is wrapped as the sole element of a "records" array
+		// of tables; concatenating the per-record [[records]] blocks is
+		// equivalent to encoding the whole dataset as one TOML document.
+		wrapped := struct {
+			Records []map[string]interface{} `toml:"records"`
+		}{Records: []map[string]interface{}{record}}
+		if err := enc.Encode(wrapped); err != nil {
+			return fmt.Errorf("failed to write TOML record: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush TOML file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+type txtConverter struct{}
+
+func init() {
+	Register(txtConverter{})
+}
+
+func (txtConverter) Name() string         { return "txt" }
+func (txtConverter) Extensions() []string { return []string{"txt"} }
+
+func (txtConverter) Convert(w io.Writer, rows RowSource, _ Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		for key, value := range record {
+			if _, err := fmt.Fprintf(writer, "%s: %v\n", key, value); err != nil {
+				return fmt.Errorf("failed to write TXT content: %w", err)
+			}
+		}
+		if _, err := fmt.Fprintln(writer); err != nil {
+			return fmt.Errorf("failed to write TXT content: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush TXT content: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
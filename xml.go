@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type xmlConverter struct{}
+
+func init() {
+	Register(xmlConverter{})
+}
+
+func (xmlConverter) Name() string         { return "xml" }
+func (xmlConverter) Extensions() []string { return []string{"xml"} }
+
+func (xmlConverter) Convert(w io.Writer, rows RowSource, _ Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	if _, err := fmt.Fprintln(writer, `<?xml version="1.0" encoding="UTF-8"?>`); err != nil {
+		return fmt.Errorf("failed to write XML header: %w", err)
+	}
+	if _, err := fmt.Fprintln(writer, "<records>"); err != nil {
+		return fmt.Errorf("failed to write XML root element: %w", err)
+	}
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintln(writer, "  <record>"); err != nil {
+			return fmt.Errorf("failed to write XML record: %w", err)
+		}
+		if err := writeXMLFields(writer, record, "    "); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(writer, "  </record>"); err != nil {
+			return fmt.Errorf("failed to write XML record: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush XML file: %w", err)
+			}
+		}
+	}
+
+	if _, err := fmt.Fprintln(writer, "</records>"); err != nil {
+		return fmt.Errorf("failed to write XML root element: %w", err)
+	}
+
+	return nil
+}
+
+// writeXMLFields writes each key/value of record as a child element,
+// recursing into nested objects and arrays. Keys are sorted for stable
+// output, since map iteration order is not.
+func writeXMLFields(w io.Writer, record map[string]interface{}, indent string) error {
+	keys := make([]string, 0, len(record))
+	for key := range record {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writeXMLValue(w, xmlTagName(key), record[key], indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeXMLValue(w io.Writer, tag string, value interface{}, indent string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, err := fmt.Fprintf(w, "%s<%s>\n", indent, tag); err != nil {
+			return fmt.Errorf("failed to write XML element: %w", err)
+		}
+		if err := writeXMLFields(w, v, indent+"  "); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(w, "%s</%s>\n", indent, tag)
+		return err
+
+	case []interface{}:
+		for _, item := range v {
+			if err := writeXMLValue(w, tag, item, indent); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		_, err := fmt.Fprintf(w, "%s<%s>%s</%s>\n", indent, tag, xmlEscape(fmt.Sprintf("%v", v)), tag)
+		return err
+	}
+}
+
+// xmlTagName turns a record key into an XML element name: dotted
+// flattened paths (e.g. from -flatten) become underscore-joined and an
+// empty key becomes "field". No other characters are changed, so a key
+// with spaces or a leading digit yields a name that is not valid XML.
+func xmlTagName(key string) string {
+	name := strings.ReplaceAll(key, ".", "_")
+	if name == "" {
+		return "field"
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+type yamlConverter struct{}
+
+func init() {
+	Register(yamlConverter{})
+}
+
+func (yamlConverter) Name() string         { return "yaml" }
+func (yamlConverter) Extensions() []string { return []string{"yaml", "yml"} }
+
+func (yamlConverter) Convert(w io.Writer, rows RowSource, _ Options) error {
+	writer := bufio.NewWriter(w)
+	defer writer.Flush()
+
+	count := 0
+	for {
+		record, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Marshaling each record as a single-element sequence and
+		// concatenating the output is equivalent to marshaling the whole
+		// dataset as one YAML sequence, without buffering it all first.
+		yamlData, err := yaml.Marshal([]map[string]interface{}{record})
+		if err != nil {
+			return fmt.Errorf("failed to marshal data to YAML: %w", err)
+		}
+		if _, err := writer.Write(yamlData); err != nil {
+			return fmt.Errorf("failed to write YAML file: %w", err)
+		}
+
+		count++
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to flush YAML file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}